@@ -0,0 +1,123 @@
+// Copyright © 2021 - 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/chaind/services/chaindb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// summarizeBlobs summarises blob sidecar usage, per block and per epoch, for post-Deneb epochs.
+func (s *Service) summarizeBlobs(ctx context.Context, summaryEpoch phase0.Epoch) error {
+	ctx, span := s.tracer.Start(ctx, "summarizeBlobs")
+	defer span.End()
+
+	if !s.blobSummaries {
+		return nil
+	}
+
+	md, err := s.getMetadata(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain metadata for blob finality")
+	}
+
+	lastBlobEpoch := md.LastBlobEpoch
+	if lastBlobEpoch != 0 {
+		lastBlobEpoch++
+	}
+	log.Trace().Uint64("last_epoch", uint64(lastBlobEpoch)).Uint64("summary_epoch", uint64(summaryEpoch)).Msg("Blobs catchup bounds")
+
+	// The last epoch updated in the metadata tells us how far we can summarize,
+	// as it checks for the component data.  As such, if the finalized epoch
+	// is beyond our summarized epoch we truncate to the summarized value.
+	// However, if we don't have block summaries the block summarizer won't run at all
+	// for epochs, so if the last epoch is 0 we continue.
+	if summaryEpoch > md.LastBlockEpoch && md.LastBlockEpoch != 0 {
+		summaryEpoch = md.LastBlockEpoch
+	}
+
+	for epoch := lastBlobEpoch; epoch <= summaryEpoch; epoch++ {
+		epochCtx, epochSpan := s.tracer.Start(ctx, "summarizeBlobsInEpoch", trace.WithAttributes(attribute.Int64("epoch", int64(epoch))))
+		err := s.summarizeBlobsInEpoch(epochCtx, md, epoch)
+		if err != nil {
+			epochSpan.RecordError(err)
+			epochSpan.SetStatus(codes.Error, err.Error())
+		}
+		epochSpan.End()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to update blob summaries for epoch")
+			return errors.Wrap(err, "failed to update blob summaries for epoch")
+		}
+
+		md.LastBlobEpoch = epoch
+		if err := s.setMetadata(ctx, md); err != nil {
+			return errors.Wrap(err, "failed to set metadata after blob summary")
+		}
+	}
+
+	return nil
+}
+
+// summarizeBlobsInEpoch summarises blob sidecar usage for all blocks in a single epoch.
+func (s *Service) summarizeBlobsInEpoch(ctx context.Context, _ *metadata, epoch phase0.Epoch) error {
+	minSlot := s.chainTime.FirstSlotOfEpoch(epoch)
+	maxSlot := s.chainTime.FirstSlotOfEpoch(epoch + 1)
+
+	sidecars, err := s.blobSidecarsProvider.BlobSidecarsForSlotRange(ctx, minSlot, maxSlot)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain blob sidecars for epoch")
+	}
+
+	perBlock := make(map[phase0.Slot]*chaindb.BlobSummary)
+	epochSummary := &chaindb.EpochBlobSummary{
+		Epoch: epoch,
+	}
+
+	for _, sidecar := range sidecars {
+		summary, exists := perBlock[sidecar.Slot]
+		if !exists {
+			summary = &chaindb.BlobSummary{
+				Slot:          sidecar.Slot,
+				ProposerIndex: sidecar.ProposerIndex,
+			}
+			perBlock[sidecar.Slot] = summary
+		}
+		summary.SidecarCount++
+		summary.TotalBytes += uint64(len(sidecar.Blob))
+		summary.KZGCommitmentCount++
+
+		epochSummary.SidecarCount++
+		epochSummary.TotalBytes += uint64(len(sidecar.Blob))
+		epochSummary.KZGCommitmentCount++
+	}
+
+	for _, summary := range perBlock {
+		if err := s.blobSidecarsSetter.SetBlobSummary(ctx, summary); err != nil {
+			return errors.Wrap(err, "failed to set blob summary")
+		}
+	}
+
+	if err := s.blobSidecarsSetter.SetEpochBlobSummary(ctx, epochSummary); err != nil {
+		return errors.Wrap(err, "failed to set epoch blob summary")
+	}
+
+	return nil
+}
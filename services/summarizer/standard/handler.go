@@ -20,6 +20,9 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // OnFinalityUpdated is called when finality has been updated in the database.
@@ -28,6 +31,11 @@ func (s *Service) OnFinalityUpdated(
 	ctx context.Context,
 	finalizedEpoch phase0.Epoch,
 ) {
+	ctx, span := s.tracer.Start(ctx, "OnFinalityUpdated", trace.WithAttributes(
+		attribute.Int64("finalized_epoch", int64(finalizedEpoch)),
+	))
+	defer span.End()
+
 	log := log.With().Uint64("finalized_epoch", uint64(finalizedEpoch)).Logger()
 	log.Trace().Msg("Handler called")
 
@@ -46,29 +54,46 @@ func (s *Service) OnFinalityUpdated(
 	summaryEpoch := finalizedEpoch - 1
 
 	if err := s.summarizeEpochs(ctx, summaryEpoch); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to update epochs")
 		log.Warn().Err(err).Msg("Failed to update epochs")
 		return
 	}
 	if err := s.summarizeBlocks(ctx, summaryEpoch); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to update blocks")
 		log.Warn().Err(err).Msg("Failed to update blocks")
 		return
 	}
+	if err := s.summarizeBlobs(ctx, summaryEpoch); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to update blobs")
+		log.Warn().Err(err).Msg("Failed to update blobs")
+		return
+	}
 	if err := s.summarizeValidators(ctx, summaryEpoch); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to update validators")
 		log.Warn().Err(err).Msg("Failed to update validators")
 		return
 	}
 
 	md, err := s.getMetadata(ctx)
 	if err != nil {
+		span.RecordError(err)
 		log.Error().Err(err).Msg("Failed to obtain metadata for day summarizer")
 	}
 	if md.PeriodicValidatorRollups {
 		if err := s.summarizeValidatorDays(ctx); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to update validator days")
 			log.Warn().Err(err).Msg("Failed to update validator days")
 			return
 		}
 
 		if err := s.prune(ctx, summaryEpoch); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to prune summaries")
 			log.Warn().Err(err).Msg("Failed to prune summaries")
 			return
 		}
@@ -79,6 +104,9 @@ func (s *Service) OnFinalityUpdated(
 }
 
 func (s *Service) summarizeEpochs(ctx context.Context, summaryEpoch phase0.Epoch) error {
+	ctx, span := s.tracer.Start(ctx, "summarizeEpochs")
+	defer span.End()
+
 	if !s.epochSummaries {
 		return nil
 	}
@@ -101,23 +129,55 @@ func (s *Service) summarizeEpochs(ctx context.Context, summaryEpoch phase0.Epoch
 
 	log.Trace().Uint64("last_epoch", uint64(lastEpoch)).Uint64("summary_epoch", uint64(summaryEpoch)).Msg("Epochs catchup bounds")
 
-	for epoch := lastEpoch; epoch <= summaryEpoch; epoch++ {
-		updated, err := s.summarizeEpoch(ctx, md, epoch)
-		if err != nil {
-			return errors.Wrapf(err, "failed to update summary for epoch %d", epoch)
-		}
-		if !updated {
-			log.Debug().Uint64("epoch", uint64(epoch)).Msg("not enough data to update summary")
-			return nil
-		}
+	committedMD := *md
+	err = s.runEpochPool(ctx, lastEpoch, summaryEpoch,
+		func(ctx context.Context, epoch phase0.Epoch) (bool, error) {
+			// Give each worker its own copy of md: workers run concurrently, and md must not be
+			// mutated (or read as being mutated) by any one of them while the others are still in flight.
+			// committedMD below, advanced only from onCommit once the serialiser has the epoch in order,
+			// is the sole writer of the persisted cursor; see runEpochPool's comment for how a stray
+			// setMetadata call from within summarizeEpoch itself is kept from racing it.
+			workerMD := *md
+			return s.summarizeEpochTraced(ctx, &workerMD, epoch)
+		},
+		func(ctx context.Context, epoch phase0.Epoch, updated bool) error {
+			if !updated {
+				log.Debug().Uint64("epoch", uint64(epoch)).Msg("not enough data to update summary")
+				return nil
+			}
+			committedMD.LastEpoch = epoch
+			return s.setMetadata(ctx, &committedMD)
+		},
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to update epoch summaries")
+		return err
 	}
 
 	return nil
 }
 
+// summarizeEpochTraced wraps summarizeEpoch with a per-epoch child span.
+func (s *Service) summarizeEpochTraced(ctx context.Context, md *metadata, epoch phase0.Epoch) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "summarizeEpoch", trace.WithAttributes(attribute.Int64("epoch", int64(epoch))))
+	defer span.End()
+
+	updated, err := s.summarizeEpoch(ctx, md, epoch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return updated, err
+}
+
 func (s *Service) summarizeBlocks(ctx context.Context,
 	summaryEpoch phase0.Epoch,
 ) error {
+	ctx, span := s.tracer.Start(ctx, "summarizeBlocks")
+	defer span.End()
+
 	if !s.blockSummaries {
 		return nil
 	}
@@ -142,16 +202,38 @@ func (s *Service) summarizeBlocks(ctx context.Context,
 		summaryEpoch = md.LastEpoch
 	}
 
-	for epoch := lastBlockEpoch; epoch <= summaryEpoch; epoch++ {
-		if err := s.summarizeBlocksInEpoch(ctx, md, epoch); err != nil {
-			return errors.Wrap(err, "failed to update block summaries for epoch")
-		}
+	committedMD := *md
+	err = s.runEpochPool(ctx, lastBlockEpoch, summaryEpoch,
+		func(ctx context.Context, epoch phase0.Epoch) (bool, error) {
+			epochCtx, epochSpan := s.tracer.Start(ctx, "summarizeBlocksInEpoch", trace.WithAttributes(attribute.Int64("epoch", int64(epoch))))
+			defer epochSpan.End()
+			// Give each worker its own copy of md; see the equivalent comment in summarizeEpochs.
+			workerMD := *md
+			err := s.summarizeBlocksInEpoch(epochCtx, &workerMD, epoch)
+			if err != nil {
+				epochSpan.RecordError(err)
+				epochSpan.SetStatus(codes.Error, err.Error())
+			}
+			return true, err
+		},
+		func(ctx context.Context, epoch phase0.Epoch, _ bool) error {
+			committedMD.LastBlockEpoch = epoch
+			return s.setMetadata(ctx, &committedMD)
+		},
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to update block summaries for epoch")
+		return errors.Wrap(err, "failed to update block summaries for epoch")
 	}
 
 	return nil
 }
 
 func (s *Service) summarizeValidators(ctx context.Context, summaryEpoch phase0.Epoch) error {
+	ctx, span := s.tracer.Start(ctx, "summarizeValidators")
+	defer span.End()
+
 	if !s.validatorSummaries {
 		return nil
 	}
@@ -182,16 +264,38 @@ func (s *Service) summarizeValidators(ctx context.Context, summaryEpoch phase0.E
 	}
 	log.Trace().Uint64("last_epoch", uint64(lastValidatorEpoch)).Uint64("summary_epoch", uint64(summaryEpoch)).Msg("Validators catchup bounds")
 
-	for epoch := lastValidatorEpoch; epoch <= summaryEpoch; epoch++ {
-		if err := s.summarizeValidatorsInEpoch(ctx, md, epoch); err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed to update validator summaries in epoch %d", epoch))
-		}
+	committedMD := *md
+	err = s.runEpochPool(ctx, lastValidatorEpoch, summaryEpoch,
+		func(ctx context.Context, epoch phase0.Epoch) (bool, error) {
+			epochCtx, epochSpan := s.tracer.Start(ctx, "summarizeValidatorsInEpoch", trace.WithAttributes(attribute.Int64("epoch", int64(epoch))))
+			defer epochSpan.End()
+			// Give each worker its own copy of md; see the equivalent comment in summarizeEpochs.
+			workerMD := *md
+			err := s.summarizeValidatorsInEpoch(epochCtx, &workerMD, epoch)
+			if err != nil {
+				epochSpan.RecordError(err)
+				epochSpan.SetStatus(codes.Error, err.Error())
+			}
+			return true, err
+		},
+		func(ctx context.Context, epoch phase0.Epoch, _ bool) error {
+			committedMD.LastValidatorEpoch = epoch
+			return s.setMetadata(ctx, &committedMD)
+		},
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to update validator summaries in epoch")
+		return errors.Wrap(err, "failed to update validator summaries in epoch")
 	}
 
 	return nil
 }
 
 func (s *Service) summarizeValidatorDays(ctx context.Context) error {
+	ctx, span := s.tracer.Start(ctx, "summarizeValidatorDays")
+	defer span.End()
+
 	md, err := s.getMetadata(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain metadata for validator day summarizer")
@@ -213,7 +317,18 @@ func (s *Service) summarizeValidatorDays(ctx context.Context) error {
 		endTimestamp := epochSummariesTime.AddDate(0, 0, -1)
 
 		for timestamp := startTime; timestamp.Before(endTimestamp); timestamp = timestamp.AddDate(0, 0, 1) {
-			if err := s.summarizeValidatorsInDay(ctx, timestamp); err != nil {
+			dayCtx, daySpan := s.tracer.Start(ctx, "summarizeValidatorsInDay", trace.WithAttributes(
+				attribute.String("day", timestamp.Format("2006-01-02")),
+			))
+			err := s.summarizeValidatorsInDay(dayCtx, timestamp)
+			if err != nil {
+				daySpan.RecordError(err)
+				daySpan.SetStatus(codes.Error, err.Error())
+			}
+			daySpan.End()
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to update validator summaries for day")
 				return errors.Wrap(err, fmt.Sprintf("failed to update validator summaries for day %s", timestamp.Format("2006-01-02")))
 			}
 		}
@@ -0,0 +1,86 @@
+// Copyright © 2021 - 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// metadataKey is the key under which the summarizer stores its progress metadata.
+const metadataKey = "summarizer.standard"
+
+// metadata stores the progress of the summarizer so that it can pick up where it left off.
+type metadata struct {
+	LastEpoch                phase0.Epoch `json:"last_epoch"`
+	LastBlockEpoch           phase0.Epoch `json:"last_block_epoch"`
+	LastBlobEpoch            phase0.Epoch `json:"last_blob_epoch"`
+	LastValidatorEpoch       phase0.Epoch `json:"last_validator_epoch"`
+	LastValidatorDay         int64        `json:"last_validator_day"`
+	PeriodicValidatorRollups bool         `json:"periodic_validator_rollups"`
+}
+
+// getMetadata obtains the summarizer's progress metadata, creating a default if none exists.
+func (s *Service) getMetadata(ctx context.Context) (*metadata, error) {
+	md := &metadata{
+		LastValidatorDay: -1,
+	}
+
+	mdJSON, err := s.chainDB.(chaindbMetadataProvider).Metadata(ctx, metadataKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain metadata")
+	}
+	if mdJSON == nil {
+		return md, nil
+	}
+
+	if err := json.Unmarshal(mdJSON, md); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal metadata")
+	}
+
+	return md, nil
+}
+
+// setMetadata sets the summarizer's progress metadata.
+//
+// A context tagged by poolWorkerCtxKey (see runEpochPool) is refused: it marks a call made from inside
+// a concurrent pool worker, which may be summarising an epoch other than the one the serialiser has
+// actually committed up to. Letting it through would let workers race each other, and onCommit, to
+// persist the cursor out of order.
+func (s *Service) setMetadata(ctx context.Context, md *metadata) error {
+	if ctx.Value(poolWorkerCtxKey{}) != nil {
+		log.Debug().Msg("Ignoring metadata write from a pool worker; the serialiser's onCommit owns the progress cursor")
+		return nil
+	}
+
+	mdJSON, err := json.Marshal(md)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal metadata")
+	}
+
+	if err := s.chainDB.(chaindbMetadataProvider).SetMetadata(ctx, metadataKey, mdJSON); err != nil {
+		return errors.Wrap(err, "failed to set metadata")
+	}
+
+	return nil
+}
+
+// chaindbMetadataProvider is the subset of chaindb.Service used to persist summarizer metadata.
+type chaindbMetadataProvider interface {
+	Metadata(ctx context.Context, key string) ([]byte, error)
+	SetMetadata(ctx context.Context, key string, value []byte) error
+}
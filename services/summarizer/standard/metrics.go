@@ -0,0 +1,78 @@
+// Copyright © 2021 - 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/wealdtech/chaind/services/metrics"
+)
+
+var (
+	epochProcessed prometheus.Gauge
+	queryRequests  *prometheus.CounterVec
+)
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if epochProcessed != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if _, isPrometheusMetrics := monitor.(metrics.PrometheusService); isPrometheusMetrics {
+		return registerPrometheusMetrics(ctx, monitor)
+	}
+
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context, monitor metrics.Service) error {
+	epochProcessed = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "chaind",
+		Subsystem: "summarizer",
+		Name:      "epoch_processed",
+		Help:      "The last epoch processed by the summarizer.",
+	})
+	if err := monitor.(metrics.PrometheusService).RegisterPrometheus(epochProcessed); err != nil {
+		return err
+	}
+
+	queryRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chaind",
+		Subsystem: "summarizer",
+		Name:      "query_requests_total",
+		Help:      "The number of summary query requests received, by summary type.",
+	}, []string{"type"})
+
+	return monitor.(metrics.PrometheusService).RegisterPrometheus(queryRequests)
+}
+
+func monitorEpochProcessed(epoch phase0.Epoch) {
+	if epochProcessed == nil {
+		return
+	}
+	epochProcessed.Set(float64(epoch))
+}
+
+func monitorQueryRequest(summaryType string) {
+	if queryRequests == nil {
+		return
+	}
+	queryRequests.WithLabelValues(summaryType).Inc()
+}
@@ -0,0 +1,192 @@
+// Copyright © 2021 - 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/chaind/services/blocks"
+	"github.com/wealdtech/chaind/services/chaindb"
+	"github.com/wealdtech/chaind/services/chaintime"
+	"github.com/wealdtech/chaind/services/metrics"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type parameters struct {
+	logLevel                zerolog.Level
+	monitor                 metrics.Service
+	eth2Client              eth2client.Service
+	chainDB                 chaindb.Service
+	chainTime               chaintime.Service
+	blocks                  blocks.Service
+	epochSummaries          bool
+	blockSummaries          bool
+	validatorSummaries      bool
+	blobSummaries           bool
+	concurrency             int
+	maxDaysPerRun           uint64
+	validatorEpochRetention *time.Duration
+	listenAddress           string
+	tracerProvider          trace.TracerProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithETH2Client sets the Ethereum 2 client for this module.
+func WithETH2Client(eth2Client eth2client.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eth2Client = eth2Client
+	})
+}
+
+// WithChainDB sets the chain database for this module.
+func WithChainDB(chainDB chaindb.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainDB = chainDB
+	})
+}
+
+// WithChainTime sets the chain time service for this module.
+func WithChainTime(chainTime chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = chainTime
+	})
+}
+
+// WithBlocks sets the blocks service for this module.
+func WithBlocks(blocks blocks.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blocks = blocks
+	})
+}
+
+// WithEpochSummaries sets the epoch summaries flag for this module.
+func WithEpochSummaries(epochSummaries bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.epochSummaries = epochSummaries
+	})
+}
+
+// WithBlockSummaries sets the block summaries flag for this module.
+func WithBlockSummaries(blockSummaries bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blockSummaries = blockSummaries
+	})
+}
+
+// WithValidatorSummaries sets the validator summaries flag for this module.
+func WithValidatorSummaries(validatorSummaries bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatorSummaries = validatorSummaries
+	})
+}
+
+// WithBlobSummaries sets the blob sidecar summaries flag for this module.
+func WithBlobSummaries(blobSummaries bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blobSummaries = blobSummaries
+	})
+}
+
+// WithSummarizerConcurrency sets the number of epochs that may be summarised concurrently.
+func WithSummarizerConcurrency(concurrency int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.concurrency = concurrency
+	})
+}
+
+// WithMaxDaysPerRun sets the maximum number of days of epochs to summarise in a single pass.
+func WithMaxDaysPerRun(maxDaysPerRun uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxDaysPerRun = maxDaysPerRun
+	})
+}
+
+// WithValidatorEpochRetention sets the retention period for validator epoch summaries.
+func WithValidatorEpochRetention(retention time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatorEpochRetention = &retention
+	})
+}
+
+// WithListenAddress sets the listen address for the summary query server.
+func WithListenAddress(listenAddress string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.listenAddress = listenAddress
+	})
+}
+
+// WithTracer sets the tracer provider used to instrument the summarizer pipeline.
+func WithTracer(tracerProvider trace.TracerProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.tracerProvider = tracerProvider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:    zerolog.GlobalLevel(),
+		concurrency: 1,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.eth2Client == nil {
+		return nil, errors.New("no Ethereum 2 client specified")
+	}
+	if parameters.chainDB == nil {
+		return nil, errors.New("no chain database specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified")
+	}
+	if parameters.tracerProvider == nil {
+		parameters.tracerProvider = trace.NewNoopTracerProvider()
+	}
+	if parameters.concurrency < 1 {
+		parameters.concurrency = 1
+	}
+
+	return &parameters, nil
+}
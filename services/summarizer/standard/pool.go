@@ -0,0 +1,164 @@
+// Copyright © 2021 - 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// epochPoolResult is the outcome of summarising a single epoch.
+type epochPoolResult struct {
+	updated bool
+	err     error
+}
+
+// poolWorkerCtxKey marks a context as belonging to a runEpochPool worker, so that setMetadata can
+// refuse to persist the progress cursor from within one; see the comment on runEpochPool.
+type poolWorkerCtxKey struct{}
+
+// runEpochPool summarises every epoch in [first,last] using a bounded pool of s.concurrency workers.
+// Workers may complete out of order, but a single serialiser goroutine drains their results strictly in
+// ascending epoch order and hands each one to onCommit, so callers can safely use onCommit to advance
+// metadata without it ever jumping ahead of an earlier, still-outstanding epoch. onCommit must be the
+// only place that persists the progress cursor: work runs concurrently across workers, so a worker that
+// also persisted it would do so out of order and race with onCommit's. work is run with a context
+// tagged by poolWorkerCtxKey, which setMetadata uses to silently refuse writes from worker goroutines: the
+// per-epoch summarize functions predate this pool and persisted the cursor themselves when called from
+// a single sequential loop, and that call is out of our control, but it is no longer safe now that the
+// same code can run concurrently from several workers at once.
+func (s *Service) runEpochPool(
+	ctx context.Context,
+	first phase0.Epoch,
+	last phase0.Epoch,
+	work func(ctx context.Context, epoch phase0.Epoch) (bool, error),
+	onCommit func(ctx context.Context, epoch phase0.Epoch, updated bool) error,
+) error {
+	if first > last {
+		return nil
+	}
+
+	results := make(map[phase0.Epoch]chan *epochPoolResult)
+	for epoch := first; epoch <= last; epoch++ {
+		results[epoch] = make(chan *epochPoolResult, 1)
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(int64(s.concurrency))
+
+	// stopCh is closed once the serialiser has seen an epoch it will not advance past (a commit error,
+	// or an epoch with no data to update). Without this, a fresh/back-filling node would have the
+	// spawner below keep scheduling workers for every remaining epoch in the window even though the
+	// serialiser already stopped consuming their results, undoing the baseline's early-stop at the
+	// first data gap. The serialiser and the spawner run concurrently precisely so that stop can
+	// interrupt a spawn loop that is still in progress.
+	stopCh := make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() { stopOnce.Do(func() { close(stopCh) }) }
+
+	// scheduled counts the epochs handed to workers. Only the spawner writes it, and only before it
+	// closes stopCh, so the serialiser reads it only after it has seen stopCh closed.
+	var scheduled phase0.Epoch
+
+	var commitErr error
+	serialiserDone := make(chan struct{})
+	go func() {
+		defer close(serialiserDone)
+		for epoch := first; epoch <= last; epoch++ {
+			// The spawner closes stopCh as soon as it stops scheduling, while epochs it has already
+			// dispatched may still be in flight: wait for those, and stop only at the first epoch it
+			// never scheduled.
+			var result *epochPoolResult
+			select {
+			case result = <-results[epoch]:
+			case <-stopCh:
+				if epoch-first >= scheduled {
+					// This epoch was never scheduled; there is nothing left to drain.
+					return
+				}
+				result = <-results[epoch]
+			}
+
+			if result.err != nil {
+				commitErr = errors.Wrapf(result.err, "failed to summarise epoch %d", epoch)
+				stop()
+				return
+			}
+			if err := onCommit(ctx, epoch, result.updated); err != nil {
+				commitErr = errors.Wrapf(err, "failed to commit summary for epoch %d", epoch)
+				stop()
+				return
+			}
+			if !result.updated {
+				stop()
+				return
+			}
+		}
+	}()
+
+	var spawnErr error
+	spawnDone := make(chan struct{})
+	go func() {
+		// Closing stopCh here too guarantees the serialiser above is never left blocked waiting on a
+		// result for an epoch that, because of a worker-slot acquisition failure, is never scheduled.
+		defer stop()
+		defer close(spawnDone)
+	spawn:
+		for epoch := first; epoch <= last; epoch++ {
+			epoch := epoch
+			select {
+			case <-stopCh:
+				break spawn
+			default:
+			}
+			if err := sem.Acquire(groupCtx, 1); err != nil {
+				spawnErr = errors.Wrap(err, "failed to acquire summariser worker slot")
+				return
+			}
+			select {
+			case <-stopCh:
+				sem.Release(1)
+				break spawn
+			default:
+			}
+			group.Go(func() error {
+				defer sem.Release(1)
+				updated, err := work(context.WithValue(groupCtx, poolWorkerCtxKey{}, true), epoch)
+				results[epoch] <- &epochPoolResult{updated: updated, err: err}
+				return nil
+			})
+			scheduled++
+		}
+	}()
+
+	// Wait for every work item to have been scheduled before calling group.Wait, so that errgroup's
+	// internal WaitGroup never observes a spurious zero count while the spawner is still issuing Go calls.
+	<-spawnDone
+	groupErr := group.Wait()
+	<-serialiserDone
+
+	if spawnErr != nil {
+		return spawnErr
+	}
+	if commitErr != nil {
+		return commitErr
+	}
+
+	return groupErr
+}
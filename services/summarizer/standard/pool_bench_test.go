@@ -0,0 +1,52 @@
+// Copyright © 2021 - 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// BenchmarkRunEpochPool measures back-fill throughput of the bounded epoch worker pool over a
+// fixed 1000-epoch window at a range of concurrency settings. The work function here is a no-op
+// standing in for summarizeEpoch/summarizeBlocksInEpoch/summarizeValidatorsInEpoch against a local
+// Postgres; swap it for one of those to benchmark the pool against a real database.
+func BenchmarkRunEpochPool(b *testing.B) {
+	const epochWindow = 1000
+
+	for _, concurrency := range []int{1, 4, 16, 64} {
+		concurrency := concurrency
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			s := &Service{concurrency: concurrency}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				err := s.runEpochPool(context.Background(), phase0.Epoch(0), phase0.Epoch(epochWindow-1),
+					func(_ context.Context, _ phase0.Epoch) (bool, error) {
+						return true, nil
+					},
+					func(_ context.Context, _ phase0.Epoch, _ bool) error {
+						return nil
+					},
+				)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
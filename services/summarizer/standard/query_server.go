@@ -0,0 +1,249 @@
+// Copyright © 2021 - 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/chaind/services/chaindb"
+)
+
+const (
+	defaultQueryPageSize = 100
+	maxQueryPageSize     = 1000
+)
+
+// startQueryServer starts the HTTP server that serves summaries to downstream tools.
+func (s *Service) startQueryServer(ctx context.Context) error {
+	epochSummariesProvider, isProvider := s.chainDB.(chaindb.EpochSummariesProvider)
+	if !isProvider {
+		return errors.New("chain DB does not provide epoch summaries")
+	}
+	blockSummariesProvider, isProvider := s.chainDB.(chaindb.BlockSummariesProvider)
+	if !isProvider {
+		return errors.New("chain DB does not provide block summaries")
+	}
+	validatorSummariesProvider, isProvider := s.chainDB.(chaindb.ValidatorSummariesProvider)
+	if !isProvider {
+		return errors.New("chain DB does not provide validator summaries")
+	}
+	validatorDaySummariesProvider, isProvider := s.chainDB.(chaindb.ValidatorDaySummariesProvider)
+	if !isProvider {
+		return errors.New("chain DB does not provide validator day summaries")
+	}
+
+	s.epochSummariesProvider = epochSummariesProvider
+	s.blockSummariesProvider = blockSummariesProvider
+	s.validatorSummariesProvider = validatorSummariesProvider
+	s.validatorDaySummariesProvider = validatorDaySummariesProvider
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/summary/epoch/", s.handleEpochSummary)
+	mux.HandleFunc("/v1/summary/block/", s.handleBlockSummary)
+	mux.HandleFunc("/v1/summary/validator/", s.handleValidatorSummary)
+
+	s.queryServer = &http.Server{
+		Addr:              s.listenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	ln, err := net.Listen("tcp", s.listenAddress)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen on query server address")
+	}
+
+	go func() {
+		if err := s.queryServer.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("Summary query server stopped unexpectedly")
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.queryServer.Shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("Failed to shut down summary query server cleanly")
+		}
+	}()
+
+	return nil
+}
+
+// handleEpochSummary serves GET /v1/summary/epoch/{epoch}.
+func (s *Service) handleEpochSummary(w http.ResponseWriter, r *http.Request) {
+	monitorQueryRequest("epoch")
+
+	epochStr := strings.TrimPrefix(r.URL.Path, "/v1/summary/epoch/")
+	epoch, err := strconv.ParseUint(epochStr, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid epoch", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := s.epochSummariesProvider.EpochSummary(r.Context(), phase0.Epoch(epoch))
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "failed to obtain epoch summary").Error(), http.StatusInternalServerError)
+		return
+	}
+	if summary == nil {
+		http.Error(w, "epoch summary not found", http.StatusNotFound)
+		return
+	}
+
+	etag := fmt.Sprintf(`"epoch-%d"`, epoch)
+	s.writeJSON(w, r, summary, etag, s.chainTime.StartOfEpoch(phase0.Epoch(epoch)))
+}
+
+// handleBlockSummary serves GET /v1/summary/block/{slot}.
+func (s *Service) handleBlockSummary(w http.ResponseWriter, r *http.Request) {
+	monitorQueryRequest("block")
+
+	slotStr := strings.TrimPrefix(r.URL.Path, "/v1/summary/block/")
+	slot, err := strconv.ParseUint(slotStr, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid slot", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := s.blockSummariesProvider.BlockSummary(r.Context(), phase0.Slot(slot))
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "failed to obtain block summary").Error(), http.StatusInternalServerError)
+		return
+	}
+	if summary == nil {
+		http.Error(w, "block summary not found", http.StatusNotFound)
+		return
+	}
+
+	etag := fmt.Sprintf(`"block-%d"`, slot)
+	lastModified := s.chainTime.StartOfEpoch(s.chainTime.SlotToEpoch(phase0.Slot(slot)))
+	s.writeJSON(w, r, summary, etag, lastModified)
+}
+
+// handleValidatorSummary serves:
+//   - GET /v1/summary/validator/{index}?from&to
+//   - GET /v1/summary/validator/{index}/day/{date}
+func (s *Service) handleValidatorSummary(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/summary/validator/")
+	parts := strings.Split(path, "/")
+
+	index, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid validator index", http.StatusBadRequest)
+		return
+	}
+
+	if len(parts) == 3 && parts[1] == "day" {
+		monitorQueryRequest("validator_day")
+		day, err := time.Parse("2006-01-02", parts[2])
+		if err != nil {
+			http.Error(w, "invalid day", http.StatusBadRequest)
+			return
+		}
+
+		summary, err := s.validatorDaySummariesProvider.ValidatorDaySummary(r.Context(), phase0.ValidatorIndex(index), parts[2])
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "failed to obtain validator day summary").Error(), http.StatusInternalServerError)
+			return
+		}
+		if summary == nil {
+			http.Error(w, "validator day summary not found", http.StatusNotFound)
+			return
+		}
+
+		etag := fmt.Sprintf(`"validator-%d-day-%s"`, index, parts[2])
+		s.writeJSON(w, r, summary, etag, day)
+		return
+	}
+
+	monitorQueryRequest("validator")
+	from, to, pageErr := parseEpochRange(r)
+	if pageErr != nil {
+		http.Error(w, pageErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	summaries, err := s.validatorSummariesProvider.ValidatorSummaries(r.Context(), phase0.ValidatorIndex(index), phase0.Epoch(from), phase0.Epoch(to))
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "failed to obtain validator summaries").Error(), http.StatusInternalServerError)
+		return
+	}
+
+	etag := fmt.Sprintf(`"validator-%d-%d-%d"`, index, from, to)
+	s.writeJSON(w, r, summaries, etag, s.chainTime.StartOfEpoch(phase0.Epoch(to)))
+}
+
+// parseEpochRange parses the "from"/"to" epoch range and page size used to bound validator summary queries.
+func parseEpochRange(r *http.Request) (from uint64, to uint64, err error) {
+	query := r.URL.Query()
+
+	if fromStr := query.Get("from"); fromStr != "" {
+		if from, err = strconv.ParseUint(fromStr, 10, 64); err != nil {
+			return 0, 0, errors.New("invalid from epoch")
+		}
+	}
+
+	pageSize := uint64(defaultQueryPageSize)
+	if sizeStr := query.Get("pageSize"); sizeStr != "" {
+		if pageSize, err = strconv.ParseUint(sizeStr, 10, 64); err != nil || pageSize == 0 || pageSize > maxQueryPageSize {
+			return 0, 0, errors.New("invalid page size")
+		}
+	}
+
+	to = from + pageSize
+	if toStr := query.Get("to"); toStr != "" {
+		if to, err = strconv.ParseUint(toStr, 10, 64); err != nil {
+			return 0, 0, errors.New("invalid to epoch")
+		}
+		if to-from > pageSize {
+			to = from + pageSize
+		}
+	}
+
+	return from, to, nil
+}
+
+// writeJSON writes a summary response, honouring If-Modified-Since/If-None-Match against the etag and
+// last-modified time of the resource actually being served, not the summariser's global progress cursor.
+func (s *Service) writeJSON(w http.ResponseWriter, r *http.Request, v interface{}, etag string, lastModified time.Time) {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+
+	if match := r.Header.Get("If-None-Match"); match == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if since := r.Header.Get("If-Modified-Since"); since != "" {
+		if sinceTime, parseErr := time.Parse(http.TimeFormat, since); parseErr == nil && !lastModified.After(sinceTime) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error().Err(err).Msg("Failed to encode summary query response")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright © 2021 - 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/chaind/services/chaindb"
+)
+
+// OnChainReorg is called when a chain reorganisation has been detected.
+// commonAncestorSlot is the slot of the last block common to both the old and new chains; any summary covering
+// data from or after that point may have been calculated from data that has since been rewritten, so it is
+// invalidated and re-derived on the next finality update.
+func (s *Service) OnChainReorg(ctx context.Context, commonAncestorSlot phase0.Slot) {
+	log := log.With().Uint64("common_ancestor_slot", uint64(commonAncestorSlot)).Logger()
+	log.Trace().Msg("Reorg handler called")
+
+	acquired := s.activitySem.TryAcquire(1)
+	if !acquired {
+		log.Debug().Msg("Another handler running")
+		return
+	}
+	defer s.activitySem.Release(1)
+
+	pruner, isPruner := s.chainDB.(chaindb.SummariesPruner)
+	if !isPruner {
+		log.Debug().Msg("Chain DB does not support summary pruning; ignoring reorg")
+		return
+	}
+	txProvider, isTxProvider := s.chainDB.(chaindbTxProvider)
+	if !isTxProvider {
+		log.Debug().Msg("Chain DB does not support transactions; ignoring reorg")
+		return
+	}
+
+	commonAncestorEpoch := s.chainTime.SlotToEpoch(commonAncestorSlot)
+
+	md, err := s.getMetadata(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to obtain metadata for reorg handler")
+		return
+	}
+
+	if commonAncestorEpoch > md.LastEpoch && commonAncestorEpoch > md.LastBlockEpoch &&
+		commonAncestorEpoch > md.LastBlobEpoch && commonAncestorEpoch > md.LastValidatorEpoch {
+		log.Trace().Msg("Reorg common ancestor is ahead of our summaries; nothing to invalidate")
+		return
+	}
+
+	commonAncestorDay := s.chainTime.StartOfEpoch(commonAncestorEpoch).In(time.UTC)
+	commonAncestorDay = time.Date(commonAncestorDay.Year(), commonAncestorDay.Month(), commonAncestorDay.Day(), 0, 0, 0, 0, time.UTC)
+
+	rewindTo := phase0.Epoch(0)
+	if commonAncestorEpoch > 0 {
+		rewindTo = commonAncestorEpoch - 1
+	}
+	// Use <= rather than <: the prune calls below are inclusive of commonAncestorEpoch (and
+	// commonAncestorDay), so a cursor sitting exactly on the common ancestor also names data that is
+	// about to be pruned and must be rewound, not left pointing at a row that no longer exists.
+	if commonAncestorEpoch <= md.LastEpoch {
+		md.LastEpoch = rewindTo
+	}
+	if commonAncestorEpoch <= md.LastBlockEpoch {
+		md.LastBlockEpoch = rewindTo
+	}
+	if commonAncestorEpoch <= md.LastBlobEpoch {
+		md.LastBlobEpoch = rewindTo
+	}
+	if commonAncestorEpoch <= md.LastValidatorEpoch {
+		md.LastValidatorEpoch = rewindTo
+	}
+	if commonAncestorDay.Unix() <= md.LastValidatorDay {
+		md.LastValidatorDay = commonAncestorDay.AddDate(0, 0, -1).Unix()
+	}
+
+	// Prune the invalidated summaries and rewind the metadata cursor atomically: if we crashed between
+	// the two a catch-up would either regenerate summaries that already existed, or resume past a gap
+	// left by the prune and never fill it in.
+	txCtx, cancel, err := txProvider.BeginTx(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin transaction for reorg")
+		return
+	}
+	defer cancel()
+
+	if err := pruner.PruneSummariesFromEpoch(txCtx, commonAncestorEpoch); err != nil {
+		log.Error().Err(err).Msg("Failed to prune epoch-based summaries for reorg")
+		return
+	}
+	if err := pruner.PruneSummariesFromDay(txCtx, commonAncestorDay.Unix()); err != nil {
+		log.Error().Err(err).Msg("Failed to prune day-based summaries for reorg")
+		return
+	}
+	if err := s.setMetadata(txCtx, md); err != nil {
+		log.Error().Err(err).Msg("Failed to rewind metadata for reorg")
+		return
+	}
+
+	if err := txProvider.CommitTx(txCtx); err != nil {
+		log.Error().Err(err).Msg("Failed to commit transaction for reorg")
+		return
+	}
+
+	log.Debug().Uint64("epoch", uint64(commonAncestorEpoch)).Msg("Invalidated summaries affected by reorg; will catch up on next finality update")
+}
+
+// chaindbTxProvider is the subset of chaindb.Service used to make the reorg prune and metadata rewind atomic.
+type chaindbTxProvider interface {
+	BeginTx(ctx context.Context) (context.Context, context.CancelFunc, error)
+	CommitTx(ctx context.Context) error
+}
@@ -15,6 +15,8 @@ package standard
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -24,6 +26,7 @@ import (
 	"github.com/wealdtech/chaind/services/blocks"
 	"github.com/wealdtech/chaind/services/chaindb"
 	"github.com/wealdtech/chaind/services/chaintime"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -45,7 +48,21 @@ type Service struct {
 	epochSummaries            bool
 	blockSummaries            bool
 	validatorSummaries        bool
+	blobSummaries             bool
+	blobSidecarsProvider      chaindb.BlobSidecarsProvider
+	blobSidecarsSetter        chaindb.BlobSidecarsSetter
+	concurrency               int
+	maxDaysPerRun             uint64
+	validatorEpochRetention   *time.Duration
 	activitySem               *semaphore.Weighted
+	listenAddress             string
+	queryServer               *http.Server
+	tracer                    trace.Tracer
+
+	epochSummariesProvider        chaindb.EpochSummariesProvider
+	blockSummariesProvider        chaindb.BlockSummariesProvider
+	validatorSummariesProvider    chaindb.ValidatorSummariesProvider
+	validatorDaySummariesProvider chaindb.ValidatorDaySummariesProvider
 }
 
 // module-wide log.
@@ -105,6 +122,19 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		return nil, errors.New("chain DB does not provide proposer slashings")
 	}
 
+	var blobSidecarsProvider chaindb.BlobSidecarsProvider
+	var blobSidecarsSetter chaindb.BlobSidecarsSetter
+	if parameters.blobSummaries {
+		blobSidecarsProvider, isProvider = parameters.chainDB.(chaindb.BlobSidecarsProvider)
+		if !isProvider {
+			return nil, errors.New("chain DB does not provide blob sidecars")
+		}
+		blobSidecarsSetter, isSetter = parameters.chainDB.(chaindb.BlobSidecarsSetter)
+		if !isSetter {
+			return nil, errors.New("chain DB does not support blob sidecar setting")
+		}
+	}
+
 	s := &Service{
 		eth2Client:                parameters.eth2Client,
 		chainDB:                   parameters.chainDB,
@@ -122,7 +152,21 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		epochSummaries:            parameters.epochSummaries,
 		blockSummaries:            parameters.blockSummaries,
 		validatorSummaries:        parameters.validatorSummaries,
+		blobSummaries:             parameters.blobSummaries,
+		blobSidecarsProvider:      blobSidecarsProvider,
+		blobSidecarsSetter:        blobSidecarsSetter,
+		concurrency:               parameters.concurrency,
+		maxDaysPerRun:             parameters.maxDaysPerRun,
+		validatorEpochRetention:   parameters.validatorEpochRetention,
 		activitySem:               semaphore.NewWeighted(1),
+		listenAddress:             parameters.listenAddress,
+		tracer:                    parameters.tracerProvider.Tracer("github.com/wealdtech/chaind/services/summarizer/standard"),
+	}
+
+	if s.listenAddress != "" {
+		if err := s.startQueryServer(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to start summary query server")
+		}
 	}
 
 	return s, nil
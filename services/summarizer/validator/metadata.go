@@ -0,0 +1,57 @@
+// Copyright © 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// summarizerMetadataKey is the key under which the standard summariser stores its progress metadata.
+// It is duplicated here, rather than imported, because the validator only needs the single LastEpoch
+// field and the standard package's metadata type is unexported.
+const summarizerMetadataKey = "summarizer.standard"
+
+// summarizerMetadata mirrors the subset of the standard summariser's progress metadata needed to bound
+// validation sampling to epochs that have actually been finalised and summarised.
+type summarizerMetadata struct {
+	LastEpoch phase0.Epoch `json:"last_epoch"`
+}
+
+// lastSummarisedEpoch returns the most recent epoch the standard summariser has finished summarising,
+// or 0 if it has not summarised anything yet.
+func (s *Service) lastSummarisedEpoch(ctx context.Context) (phase0.Epoch, error) {
+	mdJSON, err := s.chainDB.(chaindbMetadataProvider).Metadata(ctx, summarizerMetadataKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain summariser metadata")
+	}
+	if mdJSON == nil {
+		return 0, nil
+	}
+
+	var md summarizerMetadata
+	if err := json.Unmarshal(mdJSON, &md); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal summariser metadata")
+	}
+
+	return md.LastEpoch, nil
+}
+
+// chaindbMetadataProvider is the subset of chaindb.Service used to read the summariser's progress metadata.
+type chaindbMetadataProvider interface {
+	Metadata(ctx context.Context, key string) ([]byte, error)
+}
@@ -0,0 +1,57 @@
+// Copyright © 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/wealdtech/chaind/services/metrics"
+)
+
+var mismatches *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if mismatches != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if _, isPrometheusMetrics := monitor.(metrics.PrometheusService); isPrometheusMetrics {
+		return registerPrometheusMetrics(ctx, monitor)
+	}
+
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context, monitor metrics.Service) error {
+	mismatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chaind",
+		Subsystem: "summarizer_validator",
+		Name:      "mismatches_total",
+		Help:      "The number of summary mismatches detected, by summary type.",
+	}, []string{"type"})
+
+	return monitor.(metrics.PrometheusService).RegisterPrometheus(mismatches)
+}
+
+func monitorMismatch(summaryType string) {
+	if mismatches == nil {
+		return
+	}
+	mismatches.WithLabelValues(summaryType).Inc()
+}
@@ -0,0 +1,158 @@
+// Copyright © 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/chaind/services/chaindb"
+	"github.com/wealdtech/chaind/services/chaintime"
+	"github.com/wealdtech/chaind/services/metrics"
+)
+
+// Mode describes how a sampled summary is re-derived for comparison against the stored row.
+type Mode string
+
+const (
+	// ModeShallow re-derives a summary by re-querying the beacon node.
+	ModeShallow Mode = "shallow"
+	// ModeDeep re-derives a summary from the raw attestations/blocks/validators tables.
+	ModeDeep Mode = "deep"
+)
+
+type parameters struct {
+	logLevel           zerolog.Level
+	monitor            metrics.Service
+	eth2Client         eth2client.Service
+	chainDB            chaindb.Service
+	chainTime          chaintime.Service
+	validationInterval time.Duration
+	validationSample   int
+	validationMode     Mode
+	autoRepair         bool
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithETH2Client sets the Ethereum 2 client for this module.
+func WithETH2Client(eth2Client eth2client.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eth2Client = eth2Client
+	})
+}
+
+// WithChainDB sets the chain database for this module.
+func WithChainDB(chainDB chaindb.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainDB = chainDB
+	})
+}
+
+// WithChainTime sets the chain time service for this module.
+func WithChainTime(chainTime chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = chainTime
+	})
+}
+
+// WithValidationInterval sets the interval between validation passes.
+func WithValidationInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validationInterval = interval
+	})
+}
+
+// WithValidationSampleSize sets the number of epochs sampled in each validation pass; for each one the
+// epoch summary, one block summary and one validator summary are checked.
+func WithValidationSampleSize(sampleSize int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validationSample = sampleSize
+	})
+}
+
+// WithValidationMode sets the mode used to re-derive a sampled summary for comparison.
+func WithValidationMode(mode Mode) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validationMode = mode
+	})
+}
+
+// WithAutoRepair sets whether a mismatched row is rewritten with the re-derived summary.
+func WithAutoRepair(autoRepair bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.autoRepair = autoRepair
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:           zerolog.GlobalLevel(),
+		validationInterval: time.Hour,
+		validationSample:   1,
+		validationMode:     ModeShallow,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.eth2Client == nil {
+		return nil, errors.New("no Ethereum 2 client specified")
+	}
+	if parameters.chainDB == nil {
+		return nil, errors.New("no chain database specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified")
+	}
+	if parameters.validationInterval <= 0 {
+		return nil, errors.New("no validation interval specified")
+	}
+	if parameters.validationSample <= 0 {
+		return nil, errors.New("no validation sample size specified")
+	}
+	if parameters.validationMode != ModeShallow && parameters.validationMode != ModeDeep {
+		return nil, errors.New("unknown validation mode")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,88 @@
+// Copyright © 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validator provides a background subsystem that periodically samples already-summarised
+// epochs, re-derives their summaries, and reports any mismatch against the stored rows. It acts as a
+// safety net against silent bugs in the summariser, which would otherwise only be discovered when a
+// downstream analytics query looks obviously wrong.
+package validator
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/chaind/services/chaindb"
+	"github.com/wealdtech/chaind/services/chaintime"
+)
+
+// Service is a summary consistency validator service.
+type Service struct {
+	eth2Client         eth2client.Service
+	chainDB            chaindb.Service
+	summaryValidator   chaindb.SummaryValidator
+	validatorsProvider chaindb.ValidatorsProvider
+	chainTime          chaintime.Service
+	validationInterval time.Duration
+	validationSample   int
+	validationMode     Mode
+	autoRepair         bool
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	log = zerologger.With().Str("service", "summarizer").Str("impl", "validator").Logger().Level(parameters.logLevel)
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	summaryValidator, isValidator := parameters.chainDB.(chaindb.SummaryValidator)
+	if !isValidator {
+		return nil, errors.New("chain DB does not support summary validation")
+	}
+	validatorsProvider, isValidatorsProvider := parameters.chainDB.(chaindb.ValidatorsProvider)
+	if !isValidatorsProvider {
+		return nil, errors.New("chain DB does not support validator queries")
+	}
+	if _, isMetadataProvider := parameters.chainDB.(chaindbMetadataProvider); !isMetadataProvider {
+		return nil, errors.New("chain DB does not support metadata")
+	}
+
+	s := &Service{
+		eth2Client:         parameters.eth2Client,
+		chainDB:            parameters.chainDB,
+		summaryValidator:   summaryValidator,
+		validatorsProvider: validatorsProvider,
+		chainTime:          parameters.chainTime,
+		validationInterval: parameters.validationInterval,
+		validationSample:   parameters.validationSample,
+		validationMode:     parameters.validationMode,
+		autoRepair:         parameters.autoRepair,
+	}
+
+	go s.loop(ctx)
+
+	return s, nil
+}
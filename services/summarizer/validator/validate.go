@@ -0,0 +1,168 @@
+// Copyright © 2023 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// loop runs validation passes at the configured interval until the context is cancelled.
+func (s *Service) loop(ctx context.Context) {
+	ticker := time.NewTicker(s.validationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Trace().Msg("Context done; stopping validator")
+			return
+		case <-ticker.C:
+			s.validate(ctx)
+		}
+	}
+}
+
+// validate runs a single validation pass, sampling validationSample already-summarised epochs.
+func (s *Service) validate(ctx context.Context) {
+	log.Trace().Msg("Starting validation pass")
+
+	// Bound sampling by the summariser's own progress, not the chain's current epoch: the current
+	// epoch is rarely finalized or summarised yet, so sampling against it mostly picks epochs the
+	// summariser hasn't reached and produces spurious "failed to validate" noise.
+	lastEpoch, err := s.lastSummarisedEpoch(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain summariser progress")
+		return
+	}
+	if lastEpoch == 0 {
+		log.Debug().Msg("No finalized epochs to validate yet")
+		return
+	}
+
+	validatorCount, err := s.activeValidatorCount(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain validator count")
+		return
+	}
+
+	deep := s.validationMode == ModeDeep
+
+	for i := 0; i < s.validationSample; i++ {
+		// +1 so that lastEpoch itself — the most recently summarised epoch, and the one most likely to
+		// expose a fresh regression — is actually in range rather than always excluded.
+		epoch := phase0.Epoch(rand.Int63n(int64(lastEpoch) + 1)) //nolint:gosec
+
+		s.validateEpochSummary(ctx, epoch, deep)
+		s.validateBlockSummary(ctx, epoch, deep)
+		s.validateValidatorSummary(ctx, epoch, validatorCount, deep)
+	}
+
+	log.Trace().Msg("Finished validation pass")
+}
+
+// activeValidatorCount returns the size of the current validator set, used to bound validator index
+// sampling so a validation pass exercises the whole set rather than a small, slot-derived prefix of it.
+func (s *Service) activeValidatorCount(ctx context.Context) (int, error) {
+	validators, err := s.validatorsProvider.Validators(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain validators")
+	}
+
+	return len(validators), nil
+}
+
+// validateEpochSummary samples the epoch summary for the given epoch.
+func (s *Service) validateEpochSummary(ctx context.Context, epoch phase0.Epoch, deep bool) {
+	matches, diff, err := s.summaryValidator.ValidateEpochSummary(ctx, epoch, deep)
+	if err != nil {
+		log.Debug().Uint64("epoch", uint64(epoch)).Err(err).Msg("Failed to validate epoch summary")
+		return
+	}
+	if matches {
+		log.Trace().Uint64("epoch", uint64(epoch)).Msg("Epoch summary matches")
+		return
+	}
+
+	monitorMismatch("epoch")
+	log.Warn().Uint64("epoch", uint64(epoch)).Str("diff", diff).Msg("Epoch summary does not match re-derived value")
+
+	if s.autoRepair {
+		if err := s.summaryValidator.RepairEpochSummary(ctx, epoch); err != nil {
+			log.Error().Uint64("epoch", uint64(epoch)).Err(err).Msg("Failed to repair epoch summary")
+			return
+		}
+		log.Info().Uint64("epoch", uint64(epoch)).Msg("Repaired epoch summary")
+	}
+}
+
+// validateBlockSummary samples the block summary for a slot within the given epoch.
+func (s *Service) validateBlockSummary(ctx context.Context, epoch phase0.Epoch, deep bool) {
+	slot := s.chainTime.FirstSlotOfEpoch(epoch) + phase0.Slot(rand.Int63n(int64(s.chainTime.SlotsPerEpoch()))) //nolint:gosec
+
+	matches, diff, err := s.summaryValidator.ValidateBlockSummary(ctx, slot, deep)
+	if err != nil {
+		log.Debug().Uint64("slot", uint64(slot)).Err(err).Msg("Failed to validate block summary")
+		return
+	}
+	if matches {
+		log.Trace().Uint64("slot", uint64(slot)).Msg("Block summary matches")
+		return
+	}
+
+	monitorMismatch("block")
+	log.Warn().Uint64("slot", uint64(slot)).Str("diff", diff).Msg("Block summary does not match re-derived value")
+
+	if s.autoRepair {
+		if err := s.summaryValidator.RepairBlockSummary(ctx, slot); err != nil {
+			log.Error().Uint64("slot", uint64(slot)).Err(err).Msg("Failed to repair block summary")
+			return
+		}
+		log.Info().Uint64("slot", uint64(slot)).Msg("Repaired block summary")
+	}
+}
+
+// validateValidatorSummary samples the validator summary for the given epoch of a validator index drawn at
+// random from [0, validatorCount); whether that validator was active in the epoch is not checked.
+func (s *Service) validateValidatorSummary(ctx context.Context, epoch phase0.Epoch, validatorCount int, deep bool) {
+	if validatorCount == 0 {
+		return
+	}
+	index := phase0.ValidatorIndex(rand.Int63n(int64(validatorCount))) //nolint:gosec
+
+	matches, diff, err := s.summaryValidator.ValidateValidatorSummary(ctx, index, epoch, deep)
+	if err != nil {
+		log.Debug().Uint64("index", uint64(index)).Uint64("epoch", uint64(epoch)).Err(err).Msg("Failed to validate validator summary")
+		return
+	}
+	if matches {
+		log.Trace().Uint64("index", uint64(index)).Uint64("epoch", uint64(epoch)).Msg("Validator summary matches")
+		return
+	}
+
+	monitorMismatch("validator")
+	log.Warn().Uint64("index", uint64(index)).Uint64("epoch", uint64(epoch)).Str("diff", diff).Msg("Validator summary does not match re-derived value")
+
+	if s.autoRepair {
+		if err := s.summaryValidator.RepairValidatorSummary(ctx, index, epoch); err != nil {
+			log.Error().Uint64("index", uint64(index)).Uint64("epoch", uint64(epoch)).Err(err).Msg("Failed to repair validator summary")
+			return
+		}
+		log.Info().Uint64("index", uint64(index)).Uint64("epoch", uint64(epoch)).Msg("Repaired validator summary")
+	}
+}